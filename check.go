@@ -1,6 +1,7 @@
 package healthz
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -9,6 +10,13 @@ import (
 // CheckFunc is an application health check function.
 type CheckFunc func() error
 
+// CheckFuncCtx is a context-aware application health check function. Use it
+// together with RegisterCtx when the check has a meaningful way to respect
+// cancellation, e.g. a database ping or an HTTP request built with
+// req.WithContext. The context passed to it carries a deadline derived from
+// the timeout given to RegisterCtx, if any.
+type CheckFuncCtx func(ctx context.Context) error
+
 // Expired is the error status set after a status set by SetMeta has
 // expired.
 type Expired struct {
@@ -20,13 +28,45 @@ func (e Expired) Error() string {
 }
 
 type check struct {
-	mutex  sync.Mutex
-	period time.Duration
-	expiry time.Duration
-	static bool
-	fn     CheckFunc
-	err    error
-	stopch chan bool
+	mutex   sync.Mutex
+	name    string
+	period  time.Duration
+	expiry  time.Duration
+	static  bool
+	kind    Kind
+	fn      CheckFuncCtx
+	timeout time.Duration
+	err     error
+	stopch  chan bool
+
+	// lastDuration and lastSuccessAt back the Prometheus metrics exposed by
+	// Checker.PrometheusCollector. observe, if set, is called with the
+	// duration of every CheckFunc run so it can be recorded in a histogram
+	// that outlives this check, e.g. across a Deregister/Register cycle.
+	lastDuration  time.Duration
+	lastSuccessAt time.Time
+	observe       func(time.Duration)
+
+	// lastRun and lastOK back the per-check fields of the health+json
+	// format served by probeHandler: lastRun is when the check last
+	// completed (or was set, for a static check), and lastOK is whether
+	// that run succeeded, independent of the hysteresis state below.
+	lastRun time.Time
+	lastOK  bool
+
+	// failureThreshold, successThreshold and minInterval implement the
+	// hysteresis policy from CheckOptions. onTransition, if set, is called
+	// whenever state changes. For periodic checks both thresholds are
+	// always >= 1, defaulted by registerPeriodic.
+	failureThreshold int
+	successThreshold int
+	minInterval      time.Duration
+	onTransition     func(name string, from, to State)
+
+	state                State
+	consecutiveFailures  int
+	consecutiveSuccesses int
+	lastChange           time.Time
 }
 
 func (ch *check) Do() {
@@ -48,7 +88,13 @@ func (ch *check) doStatic() {
 	case <-t.C:
 		ch.mutex.Lock()
 		ch.err = Expired{expiry: ch.expiry}
+		ch.lastOK = false
+		from, changed := ch.transition(StateUnavailable)
+		onTransition, name := ch.onTransition, ch.name
 		ch.mutex.Unlock()
+		if changed && onTransition != nil {
+			onTransition(name, from, StateUnavailable)
+		}
 	case <-ch.stopch:
 	}
 }
@@ -70,10 +116,102 @@ func (ch *check) doCallbacks() {
 }
 
 func (ch *check) doOnce() {
-	// TODO: Perhaps log transitions?
+	ctx := context.Background()
+	if ch.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, ch.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := ch.run(ctx)
+	dur := time.Since(start)
+
 	ch.mutex.Lock()
-	defer ch.mutex.Unlock()
-	ch.err = ch.fn()
+
+	ch.lastDuration = dur
+	ch.lastRun = start.Add(dur)
+	ch.lastOK = err == nil
+	if err == nil {
+		ch.lastSuccessAt = start
+		ch.consecutiveFailures = 0
+		ch.consecutiveSuccesses++
+	} else {
+		ch.consecutiveSuccesses = 0
+		ch.consecutiveFailures++
+	}
+
+	target := ch.state
+	switch ch.state {
+	case StateUnavailable:
+		if err == nil && ch.consecutiveSuccesses >= ch.successThreshold {
+			target = StateAvailable
+		}
+	default: // StateUnknown, StateAvailable
+		if err != nil && ch.consecutiveFailures >= ch.failureThreshold {
+			target = StateUnavailable
+		} else if err == nil {
+			target = StateAvailable
+		}
+	}
+	from, changed := ch.transition(target)
+	newState := ch.state
+
+	// While the hysteresis policy is holding the check in its current
+	// state, keep reporting the state's own error rather than every
+	// transient one: a successful-but-not-yet-sufficient run while
+	// recovering toward SuccessThreshold must not clear the failure.
+	if newState == StateUnavailable {
+		if err != nil {
+			ch.err = err
+		}
+	} else {
+		ch.err = nil
+	}
+
+	if ch.observe != nil {
+		ch.observe(ch.lastDuration)
+	}
+
+	onTransition, name := ch.onTransition, ch.name
+	ch.mutex.Unlock()
+
+	if changed && onTransition != nil {
+		onTransition(name, from, newState)
+	}
+}
+
+// run executes fn in its own goroutine and waits for either its result or
+// ctx to be done, whichever comes first, returning ctx.Err() (typically
+// context.DeadlineExceeded) in the latter case. If fn never returns because
+// it ignores ctx, its goroutine is leaked, but the check ticker itself is
+// no longer blocked by it.
+func (ch *check) run(ctx context.Context) error {
+	resultCh := make(chan error, 1)
+	go func() { resultCh <- ch.fn(ctx) }()
+	select {
+	case err := <-resultCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// transition moves the check to the to State, unless MinInterval has not yet
+// elapsed since the last transition. Callers must hold ch.mutex. It returns
+// the previous state and whether the transition actually took place.
+func (ch *check) transition(to State) (from State, changed bool) {
+	from = ch.state
+	if to == from {
+		return from, false
+	}
+	now := time.Now()
+	if ch.minInterval > 0 && !ch.lastChange.IsZero() && now.Sub(ch.lastChange) < ch.minInterval {
+		return from, false
+	}
+	ch.state = to
+	ch.lastChange = now
+	return from, true
 }
 
 func (ch *check) Close() {
@@ -88,3 +226,52 @@ func (ch *check) Status() error {
 	defer ch.mutex.Unlock()
 	return ch.err
 }
+
+// metricsSnapshot returns the fields needed to populate the Prometheus
+// metrics for this check.
+func (ch *check) metricsSnapshot() (err error, lastSuccessAt time.Time) {
+	ch.mutex.Lock()
+	defer ch.mutex.Unlock()
+	return ch.err, ch.lastSuccessAt
+}
+
+// checkStatus returns the hysteresis bookkeeping exposed via Status.Checks.
+func (ch *check) checkStatus() CheckStatus {
+	ch.mutex.Lock()
+	defer ch.mutex.Unlock()
+	return CheckStatus{
+		State:                ch.state,
+		ConsecutiveFailures:  ch.consecutiveFailures,
+		ConsecutiveSuccesses: ch.consecutiveSuccesses,
+		LastChange:           ch.lastChange,
+		LastRun:              ch.lastRun,
+		LastOK:               ch.lastOK,
+	}
+}
+
+// healthJSONEntry renders this check's current state as a HealthCheckEntry
+// for the health+json format, alongside its health+json status string
+// ("pass", "warn" or "fail").
+func (ch *check) healthJSONEntry() (HealthCheckEntry, string) {
+	ch.mutex.Lock()
+	defer ch.mutex.Unlock()
+
+	status := healthJSONPass
+	var output string
+	if ch.err != nil {
+		output = ch.err.Error()
+		if IsWarning(ch.err) {
+			status = healthJSONWarn
+		} else {
+			status = healthJSONFail
+		}
+	}
+
+	return HealthCheckEntry{
+		Status:        status,
+		Output:        output,
+		ObservedValue: ch.lastDuration.Seconds() * 1000,
+		ObservedUnit:  "ms",
+		Time:          ch.lastRun,
+	}, status
+}
@@ -0,0 +1,44 @@
+package healthz_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wojas/go-healthz"
+)
+
+func TestRegisterCtx_timeout(t *testing.T) {
+	ch := healthz.NewChecker(nil)
+	defer ch.Close()
+
+	ch.RegisterCtx("slow", 10*time.Millisecond, 5*time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	defer ch.Deregister("slow")
+
+	time.Sleep(20 * time.Millisecond)
+
+	st := ch.Status()
+	require.False(t, st.OK)
+	require.Equal(t, context.DeadlineExceeded.Error(), st.Failures["slow"])
+}
+
+func TestRegisterCtx_noTimeout(t *testing.T) {
+	ch := healthz.NewChecker(nil)
+	defer ch.Close()
+
+	ch.RegisterCtx("fast", 10*time.Millisecond, 0, func(ctx context.Context) error {
+		return errors.New("fast failure")
+	})
+	defer ch.Deregister("fast")
+
+	time.Sleep(15 * time.Millisecond)
+
+	st := ch.Status()
+	require.False(t, st.OK)
+	require.Equal(t, "fast failure", st.Failures["fast"])
+}
@@ -1,12 +1,17 @@
 package healthz
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"runtime"
 	"runtime/debug"
+	"sort"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // Config parameterizes a Checker.
@@ -26,6 +31,12 @@ type Checker struct {
 	checks     map[string]*check
 	runtime    Runtime
 	runtimeTTL time.Duration
+
+	// checkDuration backs the healthz_check_duration_seconds histogram
+	// exposed by PrometheusCollector. It lives on the Checker, not on the
+	// individual check, so it keeps accumulating across a Deregister and
+	// Register cycle for the same check name.
+	checkDuration *prometheus.HistogramVec
 }
 
 // NewChecker creates a new Checker.
@@ -44,6 +55,11 @@ func NewChecker(config *Config) *Checker {
 		metadata:   make(map[string]interface{}),
 		checks:     make(map[string]*check),
 		runtimeTTL: config.RuntimeTTL,
+		checkDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "healthz_check_duration_seconds",
+			Help:    "Duration of CheckFunc execution for a registered health check.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"name"}),
 	}
 }
 
@@ -64,13 +80,81 @@ func (c *Checker) DeleteMeta(name string) {
 }
 
 // Register registers a check to be evaluated each given period.
+// The check affects both the liveness and the readiness probe, and flips
+// the aggregate status on the very first failure or success. Use
+// RegisterWithKind to scope it to only one probe, or RegisterWithOptions
+// for a hysteresis policy that tolerates flapping.
 func (c *Checker) Register(name string, period time.Duration, fn CheckFunc) {
+	c.RegisterWithOptions(name, period, fn, nil)
+}
+
+// RegisterWithKind registers a check to be evaluated each given period,
+// like Register, but scopes it to the given Kind so it is only taken into
+// account by the matching probe(s). This is useful to let a known-flaky or
+// non-critical dependency fail readiness without also failing liveness and
+// triggering a pod restart. It is a shortcut for RegisterWithOptions with
+// only Kind set.
+func (c *Checker) RegisterWithKind(name string, period time.Duration, kind Kind, fn CheckFunc) {
+	c.RegisterWithOptions(name, period, fn, &CheckOptions{Kind: kind})
+}
+
+// RegisterWithOptions registers a check to be evaluated each given period,
+// like Register, but allows full control over its probe Kind and hysteresis
+// policy via opt. A nil opt behaves exactly like Register.
+//
+// When FailureThreshold or SuccessThreshold is set above 1, the check only
+// transitions between State available and unavailable after that many
+// consecutive failed or successful runs, so a transiently flapping
+// dependency does not immediately flip the aggregate status. MinInterval
+// additionally rate-limits how often the check is allowed to transition.
+func (c *Checker) RegisterWithOptions(name string, period time.Duration, fn CheckFunc, opt *CheckOptions) {
 	if fn == nil {
 		panic("nil CheckFunc")
 	}
+	c.registerPeriodic(name, period, 0, func(ctx context.Context) error { return fn() }, opt)
+}
+
+// RegisterCtx registers a context-aware check to be evaluated each given
+// period, like Register, but fn receives a context.Context with a deadline
+// of timeout for each run, if timeout > 0. This bounds how long a hung
+// dependency (a database, an HTTP call) can block the check, as long as fn
+// respects ctx, e.g. by passing it down to a *sql.DB or an *http.Request. A
+// run that exceeds its deadline is recorded as context.DeadlineExceeded.
+func (c *Checker) RegisterCtx(name string, period, timeout time.Duration, fn CheckFuncCtx) {
+	c.RegisterCtxWithOptions(name, period, timeout, fn, nil)
+}
+
+// RegisterCtxWithOptions registers a check like RegisterCtx, but allows full
+// control over its probe Kind and hysteresis policy via opt, like
+// RegisterWithOptions. A nil opt behaves exactly like RegisterCtx.
+func (c *Checker) RegisterCtxWithOptions(name string, period, timeout time.Duration, fn CheckFuncCtx, opt *CheckOptions) {
+	if fn == nil {
+		panic("nil CheckFuncCtx")
+	}
+	c.registerPeriodic(name, period, timeout, fn, opt)
+}
+
+// registerPeriodic is the shared implementation behind RegisterWithOptions
+// and RegisterCtxWithOptions.
+func (c *Checker) registerPeriodic(name string, period, timeout time.Duration, fn CheckFuncCtx, opt *CheckOptions) {
 	if period == 0 {
 		period = DefaultCheckPeriod
 	}
+	if opt == nil {
+		opt = &CheckOptions{}
+	}
+	kind := opt.Kind
+	if kind == 0 {
+		kind = KindBoth
+	}
+	failureThreshold := opt.FailureThreshold
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+	successThreshold := opt.SuccessThreshold
+	if successThreshold < 1 {
+		successThreshold = 1
+	}
 
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
@@ -81,11 +165,19 @@ func (c *Checker) Register(name string, period time.Duration, fn CheckFunc) {
 	}
 
 	ch := &check{
-		static: false,
-		period: period,
-		fn:     fn,
-		err:    errors.New("pending"),
-		stopch: make(chan bool, 1),
+		name:             name,
+		static:           false,
+		period:           period,
+		timeout:          timeout,
+		kind:             kind,
+		fn:               fn,
+		err:              errors.New("pending"),
+		stopch:           make(chan bool, 1),
+		observe:          func(d time.Duration) { c.checkDuration.WithLabelValues(name).Observe(d.Seconds()) },
+		failureThreshold: failureThreshold,
+		successThreshold: successThreshold,
+		minInterval:      opt.MinInterval,
+		onTransition:     opt.OnTransition,
 	}
 
 	go ch.Do()
@@ -99,7 +191,15 @@ func (c *Checker) Register(name string, period time.Duration, fn CheckFunc) {
 // checker function to run periodically.
 // If the expiry duration is not 0, the status will be reset to Expired
 // after this duration, if no new value is set in the meantime.
+// The check affects both the liveness and the readiness probe. Use
+// SetWithKind to scope it to only one of them.
 func (c *Checker) Set(name string, err error, expiry time.Duration) {
+	c.SetWithKind(name, err, expiry, KindBoth)
+}
+
+// SetWithKind sets a static status value, like Set, but scopes it to the
+// given Kind so it is only taken into account by the matching probe(s).
+func (c *Checker) SetWithKind(name string, err error, expiry time.Duration, kind Kind) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
@@ -108,11 +208,22 @@ func (c *Checker) Set(name string, err error, expiry time.Duration) {
 		ch.Close()
 	}
 
+	state := StateAvailable
+	if err != nil {
+		state = StateUnavailable
+	}
+
 	ch := &check{
-		static: true,
-		expiry: expiry,
-		err:    err,
-		stopch: make(chan bool, 1),
+		name:       name,
+		static:     true,
+		expiry:     expiry,
+		kind:       kind,
+		err:        err,
+		stopch:     make(chan bool, 1),
+		state:      state,
+		lastChange: time.Now(),
+		lastRun:    time.Now(),
+		lastOK:     err == nil,
 	}
 
 	go ch.Do()
@@ -152,18 +263,27 @@ func (c *Checker) Close() {
 
 // Status returns the current service status.
 func (c *Checker) Status() Status {
+	return c.status(KindBoth, nil)
+}
+
+// status is the shared implementation behind Status, LivenessHandler and
+// ReadinessHandler. kind limits the aggregate result to checks registered
+// for that Kind, and exclude, if non-nil, skips checks by name.
+func (c *Checker) status(kind Kind, exclude map[string]bool) Status {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
 	now := time.Now()
-
-	if c.runtime.CollectedAt.Add(c.runtimeTTL).Before(now) {
-		c.runtime = collect()
-	}
+	c.refreshRuntime(now)
 
 	failures := make(map[string]string)
 	warnings := make(map[string]string)
+	checks := make(map[string]CheckStatus)
 	for name, check := range c.checks {
+		if exclude[name] || check.kind&kind == 0 {
+			continue
+		}
+		checks[name] = check.checkStatus()
 		if err := check.Status(); err != nil {
 			mapError(name, err, failures, warnings)
 		}
@@ -186,7 +306,60 @@ func (c *Checker) Status() Status {
 		Metadata:    c.metadata,
 		Failures:    failures,
 		Warnings:    warnings,
+		Checks:      checks,
+	}
+}
+
+// verboseReport renders a per-check plaintext report for the checks matching
+// kind, skipping any name present in exclude. It is used by the verbose=true
+// query parameter on the probe handlers.
+func (c *Checker) verboseReport(kind Kind, exclude map[string]bool) string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var names []string
+	for name, ch := range c.checks {
+		if exclude[name] || ch.kind&kind == 0 {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		if err := c.checks[name].Status(); err != nil {
+			fmt.Fprintf(&sb, "[-] %s failed: %v\n", name, err)
+		} else {
+			fmt.Fprintf(&sb, "[+] %s ok\n", name)
+		}
+	}
+	return sb.String()
+}
+
+// refreshRuntime recollects the runtime stats if the cached ones are older
+// than runtimeTTL. Callers must hold c.mutex.
+func (c *Checker) refreshRuntime(now time.Time) {
+	if c.runtime.CollectedAt.Add(c.runtimeTTL).Before(now) {
+		c.runtime = collect()
+	}
+}
+
+// snapshot returns a shallow copy of the registered checks and the current
+// runtime stats, refreshing the latter if needed. It is used by
+// PrometheusCollector to read consistent data without holding c.mutex while
+// talking to Prometheus.
+func (c *Checker) snapshot() (checks map[string]*check, rt Runtime) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.refreshRuntime(time.Now())
+
+	checks = make(map[string]*check, len(c.checks))
+	for name, ch := range c.checks {
+		checks[name] = ch
 	}
+	return checks, c.runtime
 }
 
 func mapError(name string, err error, failures, warnings map[string]string) {
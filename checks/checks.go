@@ -0,0 +1,48 @@
+// Package checks provides ready-made healthz.CheckFuncCtx constructors for
+// common external dependencies, so callers don't have to reinvent them.
+package checks
+
+import (
+	"context"
+
+	"github.com/wojas/go-healthz"
+)
+
+// Group combines several CheckFuncCtx values into one, keyed by name, so
+// they can be registered under a single healthz check name, e.g.
+//
+//	healthz.RegisterCtx("dependencies", period, timeout, checks.Group(map[string]healthz.CheckFuncCtx{
+//	    "db":    checks.SQL(db),
+//	    "cache": checks.TCPDial("redis:6379"),
+//	}))
+//
+// Sub-checks run concurrently. Any failures or warnings are reported as a
+// healthz.ScopedMultiError keyed by sub-check name, e.g. "db", which the
+// Checker reports as "dependencies/db".
+func Group(subChecks map[string]healthz.CheckFuncCtx) healthz.CheckFuncCtx {
+	return func(ctx context.Context) error {
+		type result struct {
+			name string
+			err  error
+		}
+		results := make(chan result, len(subChecks))
+		for name, fn := range subChecks {
+			name, fn := name, fn
+			go func() {
+				results <- result{name: name, err: fn(ctx)}
+			}()
+		}
+
+		me := make(healthz.ScopedMultiError)
+		for range subChecks {
+			r := <-results
+			if r.err != nil {
+				me[r.name] = r.err
+			}
+		}
+		if len(me) == 0 {
+			return nil
+		}
+		return me
+	}
+}
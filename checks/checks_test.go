@@ -0,0 +1,62 @@
+package checks_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wojas/go-healthz"
+	"github.com/wojas/go-healthz/checks"
+)
+
+func TestHTTP(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer s.Close()
+
+	require.Error(t, checks.HTTP(s.URL)(context.Background()))
+	require.NoError(t, checks.HTTP(s.URL, checks.HTTPExpectStatus(http.StatusTeapot))(context.Background()))
+}
+
+func TestTCPDial(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	require.NoError(t, checks.TCPDial(ln.Addr().String())(context.Background()))
+	require.Error(t, checks.TCPDial("127.0.0.1:1")(context.Background()))
+}
+
+func TestGoroutines(t *testing.T) {
+	require.NoError(t, checks.Goroutines(1000, 2000)(context.Background()))
+	require.True(t, healthz.IsWarning(checks.Goroutines(0, 2000)(context.Background())))
+	require.Error(t, checks.Goroutines(0, 0)(context.Background()))
+}
+
+func TestGroup(t *testing.T) {
+	fn := checks.Group(map[string]healthz.CheckFuncCtx{
+		"ok":  func(ctx context.Context) error { return nil },
+		"bad": func(ctx context.Context) error { return errors.New("boom") },
+	})
+
+	err := fn(context.Background())
+	require.Error(t, err)
+	me, ok := err.(healthz.ScopedMultiError)
+	require.True(t, ok)
+	require.Equal(t, "boom", me["bad"].Error())
+	require.NotContains(t, me, "ok")
+}
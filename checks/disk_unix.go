@@ -0,0 +1,29 @@
+//go:build !windows
+
+package checks
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+
+	"github.com/wojas/go-healthz"
+)
+
+// DiskFree returns a CheckFuncCtx that fails when the free space on the
+// filesystem holding path drops below minBytes. It relies on syscall.Statfs
+// and is only supported on Unix-like systems.
+func DiskFree(path string, minBytes uint64) healthz.CheckFuncCtx {
+	return func(ctx context.Context) error {
+		var stat syscall.Statfs_t
+		if err := syscall.Statfs(path, &stat); err != nil {
+			return err
+		}
+
+		free := uint64(stat.Bavail) * uint64(stat.Bsize)
+		if free < minBytes {
+			return fmt.Errorf("only %d bytes free on %s, want at least %d", free, path, minBytes)
+		}
+		return nil
+	}
+}
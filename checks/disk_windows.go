@@ -0,0 +1,19 @@
+//go:build windows
+
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wojas/go-healthz"
+)
+
+// DiskFree returns a CheckFuncCtx that always fails with an "unsupported"
+// error: the syscall.Statfs-based implementation used on Unix-like systems
+// has no Windows equivalent in the standard library.
+func DiskFree(path string, minBytes uint64) healthz.CheckFuncCtx {
+	return func(ctx context.Context) error {
+		return fmt.Errorf("checks.DiskFree(%q): unsupported on windows", path)
+	}
+}
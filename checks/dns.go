@@ -0,0 +1,25 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/wojas/go-healthz"
+)
+
+// DNSResolve returns a CheckFuncCtx that resolves host and fails if the
+// lookup errors or returns no addresses.
+func DNSResolve(host string) healthz.CheckFuncCtx {
+	return func(ctx context.Context) error {
+		var r net.Resolver
+		addrs, err := r.LookupHost(ctx, host)
+		if err != nil {
+			return err
+		}
+		if len(addrs) == 0 {
+			return fmt.Errorf("no addresses found for %s", host)
+		}
+		return nil
+	}
+}
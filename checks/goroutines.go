@@ -0,0 +1,25 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/wojas/go-healthz"
+)
+
+// Goroutines returns a CheckFuncCtx that warns once more than warn
+// goroutines are running, and fails once more than max are running.
+func Goroutines(warn, max int) healthz.CheckFuncCtx {
+	return func(ctx context.Context) error {
+		n := runtime.NumGoroutine()
+		switch {
+		case n > max:
+			return fmt.Errorf("%d goroutines running, want at most %d", n, max)
+		case n > warn:
+			return healthz.Warnf("%d goroutines running, soft limit is %d", n, warn)
+		default:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/wojas/go-healthz"
+)
+
+type httpCheck struct {
+	client         *http.Client
+	method         string
+	expectedStatus []int
+}
+
+// HTTPOption configures HTTP.
+type HTTPOption func(*httpCheck)
+
+// HTTPClient overrides the http.Client used by HTTP. It defaults to
+// http.DefaultClient.
+func HTTPClient(client *http.Client) HTTPOption {
+	return func(h *httpCheck) { h.client = client }
+}
+
+// HTTPMethod overrides the HTTP method used by HTTP. It defaults to GET.
+func HTTPMethod(method string) HTTPOption {
+	return func(h *httpCheck) { h.method = method }
+}
+
+// HTTPExpectStatus makes HTTP require one of the given status codes instead
+// of the default "any 2xx".
+func HTTPExpectStatus(codes ...int) HTTPOption {
+	return func(h *httpCheck) { h.expectedStatus = codes }
+}
+
+// HTTP returns a CheckFuncCtx that requests url and validates the response
+// status code, by default accepting any 2xx response. Use HTTPExpectStatus
+// to require specific codes, e.g. for an endpoint that legitimately returns
+// a 3xx or 4xx when healthy.
+func HTTP(url string, opts ...HTTPOption) healthz.CheckFuncCtx {
+	h := &httpCheck{client: http.DefaultClient, method: http.MethodGet}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, h.method, url, nil)
+		if err != nil {
+			return err
+		}
+
+		res, err := h.client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		if len(h.expectedStatus) > 0 {
+			for _, sc := range h.expectedStatus {
+				if res.StatusCode == sc {
+					return nil
+				}
+			}
+			return fmt.Errorf("unexpected status code %d from %s", res.StatusCode, url)
+		}
+
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			return fmt.Errorf("unexpected status code %d from %s", res.StatusCode, url)
+		}
+		return nil
+	}
+}
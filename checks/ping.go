@@ -0,0 +1,19 @@
+package checks
+
+import (
+	"context"
+
+	"github.com/wojas/go-healthz"
+)
+
+// Pinger is implemented by clients that can report their own liveness, such
+// as a Redis client's Ping method. Use Ping to wrap one as a CheckFuncCtx,
+// e.g. checks.Ping(func(ctx context.Context) error { return redisClient.Ping(ctx).Err() }).
+type Pinger func(ctx context.Context) error
+
+// Ping returns a CheckFuncCtx that simply calls p. It is a thin adapter for
+// clients, like most Redis libraries, that already expose a context-aware
+// Ping method but don't implement healthz.CheckFuncCtx directly.
+func Ping(p Pinger) healthz.CheckFuncCtx {
+	return healthz.CheckFuncCtx(p)
+}
@@ -0,0 +1,16 @@
+package checks
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/wojas/go-healthz"
+)
+
+// SQL returns a CheckFuncCtx that pings db via PingContext. It works with
+// any database/sql driver, not just a specific one.
+func SQL(db *sql.DB) healthz.CheckFuncCtx {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
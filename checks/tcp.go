@@ -0,0 +1,21 @@
+package checks
+
+import (
+	"context"
+	"net"
+
+	"github.com/wojas/go-healthz"
+)
+
+// TCPDial returns a CheckFuncCtx that dials addr ("host:port") over TCP and
+// immediately closes the connection.
+func TCPDial(addr string) healthz.CheckFuncCtx {
+	return func(ctx context.Context) error {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
@@ -0,0 +1,60 @@
+package healthz
+
+import "time"
+
+// Health+json status values, per the IETF "Health Check Response Format for
+// HTTP APIs" draft.
+const (
+	healthJSONPass = "pass"
+	healthJSONWarn = "warn"
+	healthJSONFail = "fail"
+)
+
+var healthJSONRank = map[string]int{
+	healthJSONPass: 0,
+	healthJSONWarn: 1,
+	healthJSONFail: 2,
+}
+
+// HealthJSON is the top-level response body served by the probe handlers
+// when a request negotiates the application/health+json content type, via
+// the Accept header or a ?format=health+json query parameter. Its shape
+// follows the IETF health+json draft: status is the worst status across
+// all checks, and checks maps a check name to a (single-element) list of
+// observations, as the draft allows for multiple observations per check.
+type HealthJSON struct {
+	Status string                        `json:"status"`
+	Checks map[string][]HealthCheckEntry `json:"checks"`
+}
+
+// HealthCheckEntry is a single observation of a check in the health+json
+// format.
+type HealthCheckEntry struct {
+	Status        string    `json:"status"`
+	Output        string    `json:"output,omitempty"`
+	ObservedValue float64   `json:"observedValue"`
+	ObservedUnit  string    `json:"observedUnit"`
+	Time          time.Time `json:"time"`
+}
+
+// healthJSON builds the health+json representation for the checks matching
+// kind, skipping any name present in exclude.
+func (c *Checker) healthJSON(kind Kind, exclude map[string]bool) HealthJSON {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	overall := healthJSONPass
+	checks := make(map[string][]HealthCheckEntry)
+	for name, ch := range c.checks {
+		if exclude[name] || ch.kind&kind == 0 {
+			continue
+		}
+		entry, status := ch.healthJSONEntry()
+		checks[name] = []HealthCheckEntry{entry}
+		if healthJSONRank[status] > healthJSONRank[overall] {
+			overall = status
+		}
+	}
+
+	return HealthJSON{Status: overall, Checks: checks}
+}
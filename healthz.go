@@ -4,6 +4,8 @@ package healthz
 import (
 	"net/http"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 const (
@@ -43,6 +45,10 @@ type Status struct {
 	Failures    map[string]string      `json:"failures"`
 	Warnings    map[string]string      `json:"warnings"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	// Checks contains hysteresis bookkeeping (State, consecutive run
+	// counters and the last transition time) for every check, keyed by
+	// name. See CheckOptions for how to configure the hysteresis policy.
+	Checks map[string]CheckStatus `json:"checks"`
 }
 
 // Runtime contains statistics about the Go's process.
@@ -72,22 +78,83 @@ func Handler() http.Handler {
 	return DefaultChecker.Handler()
 }
 
+// LivenessHandler is a shortcut for DefaultChecker.LivenessHandler. See there
+// for more information.
+func LivenessHandler() http.Handler {
+	return DefaultChecker.LivenessHandler()
+}
+
+// ReadinessHandler is a shortcut for DefaultChecker.ReadinessHandler. See
+// there for more information.
+func ReadinessHandler() http.Handler {
+	return DefaultChecker.ReadinessHandler()
+}
+
+// MetricsHandler is a shortcut for DefaultChecker.MetricsHandler. See there
+// for more information.
+func MetricsHandler() http.Handler {
+	return DefaultChecker.MetricsHandler()
+}
+
+// PrometheusCollector is a shortcut for DefaultChecker.PrometheusCollector.
+// See there for more information.
+func PrometheusCollector() prometheus.Collector {
+	return DefaultChecker.PrometheusCollector()
+}
+
 // Register is a shortcut for DefaultChecker.Register. See there for more information.
 func Register(name string, period time.Duration, fn CheckFunc) {
 	DefaultChecker.Register(name, period, fn)
 }
 
+// RegisterWithKind is a shortcut for DefaultChecker.RegisterWithKind. See
+// there for more information.
+func RegisterWithKind(name string, period time.Duration, kind Kind, fn CheckFunc) {
+	DefaultChecker.RegisterWithKind(name, period, kind, fn)
+}
+
+// RegisterWithOptions is a shortcut for DefaultChecker.RegisterWithOptions.
+// See there for more information.
+func RegisterWithOptions(name string, period time.Duration, fn CheckFunc, opt *CheckOptions) {
+	DefaultChecker.RegisterWithOptions(name, period, fn, opt)
+}
+
+// RegisterCtx is a shortcut for DefaultChecker.RegisterCtx. See there for
+// more information.
+func RegisterCtx(name string, period, timeout time.Duration, fn CheckFuncCtx) {
+	DefaultChecker.RegisterCtx(name, period, timeout, fn)
+}
+
+// RegisterCtxWithOptions is a shortcut for
+// DefaultChecker.RegisterCtxWithOptions. See there for more information.
+func RegisterCtxWithOptions(name string, period, timeout time.Duration, fn CheckFuncCtx, opt *CheckOptions) {
+	DefaultChecker.RegisterCtxWithOptions(name, period, timeout, fn, opt)
+}
+
 // Set is a shortcut for DefaultChecker.Set. See there for more information.
 func Set(name string, err error, timeout time.Duration) {
 	DefaultChecker.Set(name, err, timeout)
 }
 
+// SetWithKind is a shortcut for DefaultChecker.SetWithKind. See there for
+// more information.
+func SetWithKind(name string, err error, timeout time.Duration, kind Kind) {
+	DefaultChecker.SetWithKind(name, err, timeout, kind)
+}
+
 // RegisterRemote registers a remote /healthz endpoint that needs to be monitored.
 // See Checker.RegisterRemote for details.
 func RegisterRemote(name string, period time.Duration, url string, opt *RemoteOptions) error {
 	return DefaultChecker.RegisterRemote(name, period, url, opt)
 }
 
+// RegisterRemoteGroup registers a check that fans out to a dynamically
+// resolved set of remote /healthz endpoints. See Checker.RegisterRemoteGroup
+// for details.
+func RegisterRemoteGroup(name string, period time.Duration, resolver Resolver, opt *RemoteOptions) {
+	DefaultChecker.RegisterRemoteGroup(name, period, resolver, opt)
+}
+
 // Deregister is a shortcut for DefaultChecker.Deregister. See there for more information.
 func Deregister(name string) {
 	DefaultChecker.Deregister(name)
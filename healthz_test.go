@@ -3,8 +3,10 @@ package healthz_test
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -129,6 +131,124 @@ func TestHealthz(t *testing.T) {
 	require.Equal(t, "status expired after 200ms", status.Failures["static"])
 }
 
+func TestLivenessAndReadinessHandlers(t *testing.T) {
+	ch := healthz.NewChecker(nil)
+
+	livez := httptest.NewServer(ch.LivenessHandler())
+	defer livez.Close()
+	readyz := httptest.NewServer(ch.ReadinessHandler())
+	defer readyz.Close()
+
+	ch.RegisterWithKind("live_only", time.Second, healthz.KindLiveness, func() error {
+		return errors.New("liveness failure")
+	})
+	defer ch.Deregister("live_only")
+
+	ch.RegisterWithKind("ready_only", time.Second, healthz.KindReadiness, func() error {
+		return errors.New("readiness failure")
+	})
+	defer ch.Deregister("ready_only")
+
+	// it should only fail liveness for a check registered as KindLiveness
+	status, code, err := get(livez.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "liveness failure", status.Failures["live_only"])
+	require.Empty(t, status.Failures["ready_only"])
+
+	// it should only fail readiness for a check registered as KindReadiness
+	status, code, err = get(readyz.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "readiness failure", status.Failures["ready_only"])
+	require.Empty(t, status.Failures["live_only"])
+
+	// it should exclude a named check from the aggregate status
+	status, code, err = get(livez.URL + "?exclude=live_only")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, status.Failures["live_only"])
+
+	// it should produce a verbose plaintext report
+	res, err := http.Get(readyz.URL + "?verbose=true")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), "[-] ready_only failed: readiness failure")
+}
+
+func TestHealthJSONFormat(t *testing.T) {
+	ch := healthz.NewChecker(nil)
+	s := httptest.NewServer(ch.Handler())
+	defer s.Close()
+
+	ch.Register("ok", time.Second, func() error { return nil })
+	defer ch.Deregister("ok")
+	ch.Register("broken", time.Second, func() error { return errors.New("boom") })
+	defer ch.Deregister("broken")
+
+	time.Sleep(10 * time.Millisecond)
+
+	res, err := http.Get(s.URL + "?format=health+json")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+	require.Equal(t, "application/health+json", res.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	var hj healthz.HealthJSON
+	require.NoError(t, json.Unmarshal(body, &hj))
+	require.Equal(t, "fail", hj.Status)
+	require.Equal(t, "pass", hj.Checks["ok"][0].Status)
+	require.Equal(t, "fail", hj.Checks["broken"][0].Status)
+	require.Equal(t, "boom", hj.Checks["broken"][0].Output)
+
+	// The key order of the "checks" object must be stable: Go always
+	// marshals map keys in sorted order, so "broken" comes before "ok".
+	require.Less(t,
+		strings.Index(string(body), `"broken"`),
+		strings.Index(string(body), `"ok"`),
+	)
+
+	// Requesting via the Accept header should work the same way.
+	req, err := http.NewRequest("GET", s.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/health+json")
+	res2, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res2.Body.Close()
+	require.Equal(t, "application/health+json", res2.Header.Get("Content-Type"))
+}
+
+func TestOpenMetricsFormat(t *testing.T) {
+	ch := healthz.NewChecker(nil)
+	s := httptest.NewServer(ch.Handler())
+	defer s.Close()
+
+	ch.Register("ok", time.Second, func() error { return nil })
+	defer ch.Deregister("ok")
+	ch.Register("broken", time.Second, func() error { return errors.New("boom") })
+	defer ch.Deregister("broken")
+
+	time.Sleep(10 * time.Millisecond)
+
+	res, err := http.Get(s.URL + "?format=openmetrics")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+	require.Contains(t, res.Header.Get("Content-Type"), "application/openmetrics-text")
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), `healthz_check_up{name="ok"} 1`)
+	require.Contains(t, string(body), `healthz_check_up{name="broken"} 0`)
+	require.Contains(t, string(body), "# EOF\n")
+}
+
 func get(url string) (*healthz.Status, int, error) {
 	res, err := http.Get(url)
 	if err != nil {
@@ -3,36 +3,148 @@ package healthz
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Content negotiation formats supported by probeHandler, selected via the
+// Accept header or a ?format= query parameter.
+const (
+	formatJSON        = "json"
+	formatHealthJSON  = "health+json"
+	formatOpenMetrics = "openmetrics"
 )
 
+// negotiateFormat picks the response format for a probe request: an
+// explicit ?format= query parameter wins, otherwise the Accept header is
+// checked for the application/health+json or application/openmetrics-text
+// media types, and the plain JSON format is used as the fallback.
+func negotiateFormat(r *http.Request) string {
+	if f := r.URL.Query().Get("format"); f != "" {
+		// net/url decodes an unescaped '+' in a query value as a space, so
+		// "?format=health+json" (the documented, copy-pasteable form)
+		// arrives here as "health json". Undo that so it still matches
+		// formatHealthJSON; a properly percent-encoded value is unaffected.
+		return strings.ReplaceAll(f, " ", "+")
+	}
+	accept := r.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, "application/health+json"):
+		return formatHealthJSON
+	case strings.Contains(accept, "application/openmetrics-text"):
+		return formatOpenMetrics
+	default:
+		return formatJSON
+	}
+}
+
 // Handler returns an HTTP handler to be used as a health check endpoint. If the
 // application is healthy and all the registered check pass, it returns a `200 OK`
 // HTTP status code, otherwise, it fails with a `503 Service Unavailable` code.
 // All responses contain a JSON encoded payload with information about the
 // runtime system, current checks statuses and some configurable metadata.
+//
+// The handler accepts a repeatable `exclude` query parameter to skip specific
+// checks from the aggregate status, and a `verbose=true` query parameter to
+// get a per-check plaintext report instead of the JSON payload.
+//
+// It also supports content negotiation for two richer formats: a
+// Kubernetes-style `health+json` payload following the IETF health+json
+// draft, and a plaintext OpenMetrics exposition. Request either with a
+// `?format=health+json` or `?format=openmetrics` query parameter, or an
+// `Accept: application/health+json` or `Accept: application/openmetrics-text`
+// header.
 func (c *Checker) Handler() http.Handler {
-	return http.HandlerFunc(c.handle)
+	return c.probeHandler(KindBoth)
 }
 
-func (c *Checker) handle(w http.ResponseWriter, r *http.Request) {
-	// TODO: param to convert warning into errors?
+// LivenessHandler returns an HTTP handler for a Kubernetes-style liveness
+// probe (e.g. served on /livez). It only takes into account checks
+// registered for KindLiveness or KindBoth, so a failing readiness-only check
+// does not cause a pod restart. It supports the same `exclude` and
+// `verbose` query parameters as Handler.
+func (c *Checker) LivenessHandler() http.Handler {
+	return c.probeHandler(KindLiveness)
+}
 
-	status := c.Status()
+// ReadinessHandler returns an HTTP handler for a Kubernetes-style readiness
+// probe (e.g. served on /readyz). It only takes into account checks
+// registered for KindReadiness or KindBoth. It supports the same `exclude`
+// and `verbose` query parameters as Handler.
+func (c *Checker) ReadinessHandler() http.Handler {
+	return c.probeHandler(KindReadiness)
+}
 
-	code := http.StatusOK
-	if !status.OK {
-		code = http.StatusServiceUnavailable
-	}
+// MetricsHandler returns an HTTP handler serving the Prometheus metrics
+// produced by PrometheusCollector, suitable for mounting at /metrics.
+func (c *Checker) MetricsHandler() http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(c.PrometheusCollector())
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
 
-	data, err := json.Marshal(status)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		_, _ = fmt.Fprintf(w, "internal healthz error: %v", err)
-		return
-	}
+// HandleMetrics registers both the health check handler and the Prometheus
+// metrics handler on mux, so a service only needs a single mux to serve
+// both /healthz and /metrics.
+func (c *Checker) HandleMetrics(mux *http.ServeMux, healthzPath, metricsPath string) {
+	mux.Handle(healthzPath, c.Handler())
+	mux.Handle(metricsPath, c.MetricsHandler())
+}
+
+func (c *Checker) probeHandler(kind Kind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// TODO: param to convert warning into errors?
+
+		exclude := make(map[string]bool)
+		for _, name := range r.URL.Query()["exclude"] {
+			exclude[name] = true
+		}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(code)
-	_, _ = w.Write(data)
+		status := c.status(kind, exclude)
+
+		code := http.StatusOK
+		if !status.OK {
+			code = http.StatusServiceUnavailable
+		}
+
+		if r.URL.Query().Get("verbose") == "true" {
+			report := c.verboseReport(kind, exclude)
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.WriteHeader(code)
+			_, _ = io.WriteString(w, report)
+			return
+		}
+
+		switch negotiateFormat(r) {
+		case formatHealthJSON:
+			data, err := json.Marshal(c.healthJSON(kind, exclude))
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = fmt.Fprintf(w, "internal healthz error: %v", err)
+				return
+			}
+			w.Header().Set("Content-Type", "application/health+json")
+			w.WriteHeader(code)
+			_, _ = w.Write(data)
+		case formatOpenMetrics:
+			report := c.openMetricsReport(kind, exclude)
+			w.Header().Set("Content-Type", "application/openmetrics-text; version=1.0.0; charset=utf-8")
+			w.WriteHeader(code)
+			_, _ = io.WriteString(w, report)
+		default:
+			data, err := json.Marshal(status)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = fmt.Fprintf(w, "internal healthz error: %v", err)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(code)
+			_, _ = w.Write(data)
+		}
+	}
 }
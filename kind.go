@@ -0,0 +1,20 @@
+package healthz
+
+// Kind identifies which Kubernetes-style probe(s) a check participates in.
+// A check registered with Register or Set participates in both probes, but
+// RegisterWithKind and SetWithKind allow a check to be scoped to only one of
+// them, so a slow or non-critical dependency can fail readiness without
+// causing a liveness probe (and thus a pod restart) to fail.
+type Kind int
+
+const (
+	// KindLiveness marks a check as affecting the liveness probe (/livez).
+	KindLiveness Kind = 1 << iota
+	// KindReadiness marks a check as affecting the readiness probe (/readyz).
+	KindReadiness
+
+	// KindBoth marks a check as affecting both the liveness and the
+	// readiness probe, as well as the combined /healthz endpoint. This is
+	// the default used by Register and Set.
+	KindBoth = KindLiveness | KindReadiness
+)
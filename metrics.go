@@ -0,0 +1,100 @@
+package healthz
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	checkStatusDesc = prometheus.NewDesc(
+		"healthz_check_status",
+		"Status of a registered health check: 0=ok, 1=warn, 2=fail.",
+		[]string{"name", "kind"}, nil,
+	)
+	checkLastSuccessDesc = prometheus.NewDesc(
+		"healthz_check_last_success_timestamp_seconds",
+		"Unix timestamp of the last successful run of a registered health check.",
+		[]string{"name"}, nil,
+	)
+	goroutinesCountDesc = prometheus.NewDesc(
+		"healthz_goroutines_count", "Number of running goroutines.", nil, nil,
+	)
+	heapObjectsCountDesc = prometheus.NewDesc(
+		"healthz_heap_objects_count", "Number of allocated heap objects.", nil, nil,
+	)
+	allocBytesDesc = prometheus.NewDesc(
+		"healthz_alloc_bytes", "Bytes of allocated and still in-use heap objects.", nil, nil,
+	)
+	totalAllocBytesDesc = prometheus.NewDesc(
+		"healthz_total_alloc_bytes", "Cumulative bytes allocated for heap objects.", nil, nil,
+	)
+)
+
+// prometheusCollector adapts a Checker to the prometheus.Collector
+// interface. It is returned by Checker.PrometheusCollector.
+type prometheusCollector struct {
+	checker *Checker
+}
+
+// PrometheusCollector returns a prometheus.Collector that exposes the status
+// and timing of every registered check, as well as the collected runtime
+// stats, as Prometheus metrics. Register it with a prometheus.Registerer,
+// or use MetricsHandler to serve it directly.
+//
+// The healthz_check_duration_seconds histogram is populated by timing every
+// CheckFunc execution in check.doOnce, so it keeps accumulating across
+// scrapes and across a Deregister/Register cycle for the same check name.
+func (c *Checker) PrometheusCollector() prometheus.Collector {
+	return &prometheusCollector{checker: c}
+}
+
+func (pc *prometheusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- checkStatusDesc
+	ch <- checkLastSuccessDesc
+	ch <- goroutinesCountDesc
+	ch <- heapObjectsCountDesc
+	ch <- allocBytesDesc
+	ch <- totalAllocBytesDesc
+	pc.checker.checkDuration.Describe(ch)
+}
+
+func (pc *prometheusCollector) Collect(ch chan<- prometheus.Metric) {
+	checks, rt := pc.checker.snapshot()
+
+	for name, c := range checks {
+		err, lastSuccessAt := c.metricsSnapshot()
+
+		statusValue := 0.0
+		if err != nil {
+			if IsWarning(err) {
+				statusValue = 1
+			} else {
+				statusValue = 2
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(checkStatusDesc, prometheus.GaugeValue, statusValue, name, kindString(c.kind))
+
+		if !lastSuccessAt.IsZero() {
+			ch <- prometheus.MustNewConstMetric(checkLastSuccessDesc, prometheus.GaugeValue, float64(lastSuccessAt.Unix()), name)
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(goroutinesCountDesc, prometheus.GaugeValue, float64(rt.GoroutinesCount))
+	ch <- prometheus.MustNewConstMetric(heapObjectsCountDesc, prometheus.GaugeValue, float64(rt.HeapObjectsCount))
+	ch <- prometheus.MustNewConstMetric(allocBytesDesc, prometheus.GaugeValue, float64(rt.AllocBytes))
+	ch <- prometheus.MustNewConstMetric(totalAllocBytesDesc, prometheus.GaugeValue, float64(rt.TotalAllocBytes))
+
+	pc.checker.checkDuration.Collect(ch)
+}
+
+func kindString(k Kind) string {
+	switch k {
+	case KindLiveness:
+		return "liveness"
+	case KindReadiness:
+		return "readiness"
+	case KindBoth:
+		return "both"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,30 @@
+package healthz_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"github.com/wojas/go-healthz"
+)
+
+func TestPrometheusCollector(t *testing.T) {
+	ch := healthz.NewChecker(nil)
+	defer ch.Close()
+
+	ch.Register("ok_check", 10*time.Millisecond, func() error { return nil })
+	ch.Register("failing_check", 10*time.Millisecond, func() error { return errors.New("boom") })
+
+	time.Sleep(20 * time.Millisecond)
+
+	err := testutil.CollectAndCompare(ch.PrometheusCollector(), strings.NewReader(`
+# HELP healthz_check_status Status of a registered health check: 0=ok, 1=warn, 2=fail.
+# TYPE healthz_check_status gauge
+healthz_check_status{kind="both",name="failing_check"} 2
+healthz_check_status{kind="both",name="ok_check"} 0
+`), "healthz_check_status")
+	require.NoError(t, err)
+}
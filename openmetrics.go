@@ -0,0 +1,39 @@
+package healthz
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// openMetricsReport renders the checks matching kind, skipping any name
+// present in exclude, as an OpenMetrics text exposition. It is served
+// directly by the probe handlers for scrapers that only have access to the
+// healthz endpoint, as a lighter-weight alternative to wiring up
+// MetricsHandler and a separate Prometheus registry.
+func (c *Checker) openMetricsReport(kind Kind, exclude map[string]bool) string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var names []string
+	for name, ch := range c.checks {
+		if exclude[name] || ch.kind&kind == 0 {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString("# TYPE healthz_check_up gauge\n")
+	sb.WriteString("# HELP healthz_check_up 1 if the check currently passes, 0 otherwise.\n")
+	for _, name := range names {
+		up := 1
+		if c.checks[name].Status() != nil {
+			up = 0
+		}
+		fmt.Fprintf(&sb, "healthz_check_up{name=%q} %d\n", name, up)
+	}
+	sb.WriteString("# EOF\n")
+	return sb.String()
+}
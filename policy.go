@@ -0,0 +1,109 @@
+package healthz
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// State represents whether a check is currently considered available or
+// unavailable, after applying its hysteresis policy (see CheckOptions).
+type State int
+
+const (
+	// StateUnknown is the state of a check that has not completed a run yet,
+	// or whose runs so far have all failed fewer than FailureThreshold times.
+	StateUnknown State = iota
+	// StateAvailable means the check currently passes, or has not yet
+	// accumulated FailureThreshold consecutive failures.
+	StateAvailable
+	// StateUnavailable means the check has accumulated at least
+	// FailureThreshold consecutive failures and has not yet recovered.
+	StateUnavailable
+)
+
+func (s State) String() string {
+	switch s {
+	case StateAvailable:
+		return "available"
+	case StateUnavailable:
+		return "unavailable"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalJSON encodes a State as its string representation, so it reads
+// naturally in the JSON served by Handler.
+func (s State) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + s.String() + `"`), nil
+}
+
+// UnmarshalJSON decodes a State from its string representation, the inverse
+// of MarshalJSON. This is needed for RegisterRemote and RegisterRemoteGroup,
+// which decode a remote instance's Status JSON (including its per-check
+// Checks) back into Go values. An unrecognized string decodes to
+// StateUnknown.
+func (s *State) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	switch str {
+	case "available":
+		*s = StateAvailable
+	case "unavailable":
+		*s = StateUnavailable
+	default:
+		*s = StateUnknown
+	}
+	return nil
+}
+
+// CheckOptions configures the probe Kind and hysteresis policy of a check
+// registered with RegisterWithOptions. The zero value behaves like Register:
+// KindBoth, and the check flips state on the very first failure or success.
+type CheckOptions struct {
+	// Kind scopes the check to the liveness and/or readiness probe.
+	// The zero value defaults to KindBoth.
+	Kind Kind
+
+	// FailureThreshold is the number of consecutive failed runs required
+	// before the check transitions from StateAvailable to
+	// StateUnavailable. Values below 1 are treated as 1, which matches the
+	// behavior of Register: fail immediately.
+	FailureThreshold int
+
+	// SuccessThreshold is the number of consecutive successful runs
+	// required before the check transitions back from StateUnavailable to
+	// StateAvailable. Values below 1 are treated as 1.
+	SuccessThreshold int
+
+	// MinInterval is the minimum time that must elapse since the last
+	// state transition before another one is allowed. A transition that
+	// would otherwise happen sooner is deferred, so a check flapping faster
+	// than MinInterval cannot change the reported state. Zero disables
+	// this.
+	MinInterval time.Duration
+
+	// OnTransition, if set, is called with the check name and its previous
+	// and new State whenever the check transitions, instead of on every
+	// run. Useful to log or alert on actual state changes.
+	OnTransition func(name string, from, to State)
+}
+
+// CheckStatus contains hysteresis bookkeeping for a single registered check.
+// It is exposed per check name via Status.Checks, alongside the aggregate
+// failures and warnings.
+type CheckStatus struct {
+	State                State     `json:"state"`
+	ConsecutiveFailures  int       `json:"consecutive_failures"`
+	ConsecutiveSuccesses int       `json:"consecutive_successes"`
+	LastChange           time.Time `json:"last_change"`
+	// LastRun is when the check last completed a run (or was set, for a
+	// static check set via Set/SetWithKind).
+	LastRun time.Time `json:"last_run"`
+	// LastOK is whether that last run succeeded, independent of State:
+	// a flapping check held in StateUnavailable by its FailureThreshold
+	// can still have LastOK true.
+	LastOK bool `json:"last_ok"`
+}
@@ -0,0 +1,84 @@
+package healthz_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wojas/go-healthz"
+)
+
+// TestRegisterWithOptions_hysteresis drives the check's CheckFunc by hand
+// through an unbuffered channel, so each run() call deterministically
+// triggers exactly one evaluation instead of racing a fixed sleep against
+// the check's own ticker. require.Eventually then waits for that
+// evaluation's effects to become visible via Status, with no guessed
+// timings anywhere.
+func TestRegisterWithOptions_hysteresis(t *testing.T) {
+	ch := healthz.NewChecker(nil)
+	defer ch.Close()
+
+	results := make(chan error)
+	var mu sync.Mutex
+	var transitions []string
+
+	ch.RegisterWithOptions("flaky", time.Millisecond, func() error {
+		return <-results
+	}, &healthz.CheckOptions{
+		FailureThreshold: 3,
+		SuccessThreshold: 2,
+		OnTransition: func(name string, from, to healthz.State) {
+			mu.Lock()
+			transitions = append(transitions, name+":"+from.String()+"->"+to.String())
+			mu.Unlock()
+		},
+	})
+
+	run := func(err error) { results <- err }
+	transitionsSoFar := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), transitions...)
+	}
+
+	// First run succeeds: the check becomes available immediately.
+	run(nil)
+	require.Eventually(t, func() bool {
+		return ch.Status().Checks["flaky"].State == healthz.StateAvailable
+	}, time.Second, time.Millisecond)
+	require.True(t, ch.Status().OK)
+
+	// Two consecutive failures stay below FailureThreshold of 3.
+	run(errors.New("down"))
+	run(errors.New("down"))
+	require.Eventually(t, func() bool {
+		return ch.Status().Checks["flaky"].ConsecutiveFailures == 2
+	}, time.Second, time.Millisecond)
+	require.True(t, ch.Status().OK, "should tolerate failures below FailureThreshold")
+
+	// The third consecutive failure crosses FailureThreshold.
+	run(errors.New("down"))
+	require.Eventually(t, func() bool {
+		return ch.Status().Checks["flaky"].State == healthz.StateUnavailable
+	}, time.Second, time.Millisecond)
+	require.False(t, ch.Status().OK)
+
+	// One success is below SuccessThreshold of 2: must not recover yet.
+	run(nil)
+	require.Eventually(t, func() bool {
+		return ch.Status().Checks["flaky"].ConsecutiveSuccesses == 1
+	}, time.Second, time.Millisecond)
+	require.False(t, ch.Status().OK, "should not recover before SuccessThreshold")
+
+	// The second consecutive success crosses SuccessThreshold.
+	run(nil)
+	require.Eventually(t, func() bool {
+		return ch.Status().Checks["flaky"].State == healthz.StateAvailable
+	}, time.Second, time.Millisecond)
+	require.True(t, ch.Status().OK)
+
+	require.Contains(t, transitionsSoFar(), "flaky:available->unavailable")
+	require.Contains(t, transitionsSoFar(), "flaky:unavailable->available")
+}
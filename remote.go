@@ -1,21 +1,25 @@
 package healthz
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 )
 
-// RemoteOptions are options passed to RegisterRemote
+// RemoteOptions are options passed to RegisterRemote and RegisterRemoteGroup.
 type RemoteOptions struct {
 	// Client allows you to override the default http.Client
 	Client *http.Client // optional client override
 
-	// Timeout allows you to override the default timeout of RemoteDefaultTimeout
-	// used by RegisterRemote. If the Client is overridden, this does nothing.
+	// Timeout allows you to override the default timeout of
+	// RemoteDefaultTimeout used by RegisterRemote and RegisterRemoteGroup.
+	// It is applied as a context deadline on the request, via RegisterCtx,
+	// so it takes effect even when Client is overridden.
 	Timeout time.Duration // optional timeout, if the default is not OK
 
 	// AsWarnings instructs RegisterRemote to downgrade any remote errors to
@@ -45,86 +49,161 @@ const (
 // failures and warnings keys could be found, this check returns a single error
 // for this endpoint with the requested name.
 func (c *Checker) RegisterRemote(name string, period time.Duration, url string, opt *RemoteOptions) error {
-	var client *http.Client
+	client, timeout, asWarnings, errorf := remoteSettings(opt)
+
+	c.RegisterCtx(name, period, timeout, func(ctx context.Context) error {
+		return checkRemoteOnce(ctx, client, url, asWarnings, errorf, opt)
+	})
+
+	return nil
+}
+
+// remoteSettings extracts the client, timeout and error-reporting behavior
+// shared by RegisterRemote and RegisterRemoteGroup from a RemoteOptions.
+func remoteSettings(opt *RemoteOptions) (client *http.Client, timeout time.Duration, asWarnings bool, errorf func(format string, args ...interface{}) error) {
 	if opt != nil && opt.Client != nil {
 		client = opt.Client
 	} else {
-		timeout := RemoteDefaultTimeout
-		if opt != nil && opt.Timeout > 0 {
-			timeout = opt.Timeout
-		}
-		client = &http.Client{
-			Timeout: timeout,
-		}
+		client = &http.Client{}
 	}
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return err
+	timeout = RemoteDefaultTimeout
+	if opt != nil && opt.Timeout > 0 {
+		timeout = opt.Timeout
 	}
 
-	asWarnings := false
-	errorf := fmt.Errorf
+	errorf = fmt.Errorf
 	if opt != nil {
 		asWarnings = opt.AsWarnings
 		errorf = Warnf
 	}
 
-	c.Register(name, period, func() error {
-		res, err := client.Do(req)
-		if err != nil {
-			return err
+	return client, timeout, asWarnings, errorf
+}
+
+// checkRemoteOnce fetches the healthz endpoint at rawURL and interprets the
+// response: if it looks like a healthz.Status JSON payload, its own
+// failures and warnings are extracted into a ScopedMultiError; otherwise the
+// HTTP status code alone determines success. The context deadline set up by
+// RegisterCtx bounds the whole request.
+func checkRemoteOnce(ctx context.Context, client *http.Client, rawURL string, asWarnings bool, errorf func(format string, args ...interface{}) error, opt *RemoteOptions) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	// Accept error codes in the 2xx and 5xx range
+	sc := res.StatusCode
+	if sc < 200 || (sc >= 300 && sc < 500) || sc >= 600 {
+		if sc == 404 && opt != nil && opt.Warn404 {
+			return Warnf("remote healthz endpoint does not exist")
 		}
-		body, err := io.ReadAll(res.Body)
-		if err != nil {
-			return err
+		return errorf("unexpected healthz http status code: %d", sc)
+	}
+	remoteOK := sc < 300
+
+	// Try to decode as json
+	var st Status
+	if err := json.Unmarshal(body, &st); err != nil {
+		// Not JSON or not the expected format, base on http status code
+		if remoteOK {
+			return nil
+		} else {
+			return errorf("remote http code %d, contents:\n%s", sc, string(body))
 		}
+	}
+	if !remoteOK && len(st.Failures) == 0 {
+		// No failures listed, but we got an error status code, so the remote
+		// json is not compatible with our json.
+		return errorf("remote http code %d, contents:\n%s", sc, string(body))
+	}
 
-		// Accept error codes in the 2xx and 5xx range
-		sc := res.StatusCode
-		if sc < 200 || (sc >= 300 && sc < 500) || sc >= 600 {
-			if sc == 404 && opt != nil && opt.Warn404 {
-				return Warnf("remote healthz endpoint does not exist")
-			}
-			return errorf("unexpected healthz http status code: %d", sc)
+	// Extract failures and warnings from another instance that uses the
+	// same reporting format.
+	me := make(ScopedMultiError)
+	for key, msg := range st.Failures {
+		if asWarnings {
+			// Downgrade to warning if requested in RemoteOptions
+			me[key] = Warn(msg)
+		} else {
+			me[key] = errors.New(msg)
 		}
-		remoteOK := sc < 300
-
-		// Try to decode as json
-		var st Status
-		if err := json.Unmarshal(body, &st); err != nil {
-			// Not JSON or not the expected format, base on http status code
-			if remoteOK {
-				return nil
-			} else {
-				return errorf("remote http code %d, contents:\n%s", sc, string(body))
-			}
+	}
+	for key, msg := range st.Warnings {
+		me[key] = Warn(msg)
+	}
+	if len(me) == 0 {
+		return nil
+	}
+	return me
+}
+
+// RegisterRemoteGroup registers a check that fans out to a dynamically
+// resolved set of remote /healthz endpoints, such as the backends behind a
+// load balancer. resolver is re-resolved on every run, so instances added or
+// removed by autoscaling are picked up without restarting the process.
+//
+// Every resolved endpoint is polled concurrently the same way RegisterRemote
+// polls a single one. Failures and warnings are aggregated into a
+// ScopedMultiError keyed by the endpoint's host:port, so they end up as
+// "name/host:port" or, if the endpoint itself reports failures in the
+// healthz.Status format, "name/host:port/remote-key" in the status reported
+// by this instance.
+func (c *Checker) RegisterRemoteGroup(name string, period time.Duration, resolver Resolver, opt *RemoteOptions) {
+	client, timeout, asWarnings, errorf := remoteSettings(opt)
+
+	c.RegisterCtx(name, period, timeout, func(ctx context.Context) error {
+		urls, err := resolver.Resolve(ctx)
+		if err != nil {
+			return fmt.Errorf("resolving endpoints for %q: %w", name, err)
 		}
-		if !remoteOK && len(st.Failures) == 0 {
-			// No failures listed, but we got an error status code, so the remote
-			// json is not compatible with our json.
-			return errorf("remote http code %d, contents:\n%s", sc, string(body))
+		if len(urls) == 0 {
+			return fmt.Errorf("resolver for %q returned no endpoints", name)
+		}
+
+		type result struct {
+			key string
+			err error
+		}
+		results := make(chan result, len(urls))
+		for _, u := range urls {
+			u := u
+			go func() {
+				results <- result{key: instanceKey(u), err: checkRemoteOnce(ctx, client, u, asWarnings, errorf, opt)}
+			}()
 		}
 
-		// Extract failures and warnings from another instance that uses the
-		// same reporting format.
 		me := make(ScopedMultiError)
-		for key, msg := range st.Failures {
-			if asWarnings {
-				// Downgrade to warning if requested in RemoteOptions
-				me[key] = Warn(msg)
-			} else {
-				me[key] = errors.New(msg)
+		for range urls {
+			r := <-results
+			if r.err != nil {
+				me[r.key] = r.err
 			}
 		}
-		for key, msg := range st.Warnings {
-			me[key] = Warn(msg)
-		}
 		if len(me) == 0 {
 			return nil
 		}
 		return me
 	})
+}
 
-	return nil
+// instanceKey derives the ScopedMultiError key for an endpoint URL: its
+// host:port, or the raw URL if it fails to parse.
+func instanceKey(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return u.Host
 }
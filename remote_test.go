@@ -73,3 +73,41 @@ func TestRegisterRemote_asWarnings(t *testing.T) {
 	assert.Equal(t, "e2", st.Warnings["remote/multi/e2"])
 	assert.Equal(t, "w1", st.Warnings["remote/multi/w1"])
 }
+
+func TestRegisterRemoteGroup(t *testing.T) {
+	good := NewChecker(nil)
+	defer good.Close()
+	goodServer := httptest.NewServer(good.Handler())
+	defer goodServer.Close()
+
+	bad := NewChecker(nil)
+	defer bad.Close()
+	bad.Set("down", errors.New("down value"), 0)
+	badServer := httptest.NewServer(bad.Handler())
+	defer badServer.Close()
+
+	local := NewChecker(nil)
+	defer local.Close()
+	resolver := StaticResolver{goodServer.URL, badServer.URL}
+	local.RegisterRemoteGroup("group", time.Second, resolver, nil)
+
+	time.Sleep(10 * time.Millisecond)
+
+	st := local.Status()
+	assert.False(t, st.OK)
+	assert.Len(t, st.Failures, 1)
+	badKey := "group/" + badServer.Listener.Addr().String() + "/down"
+	assert.Equal(t, "down value", st.Failures[badKey])
+}
+
+func TestRegisterRemoteGroup_noEndpoints(t *testing.T) {
+	local := NewChecker(nil)
+	defer local.Close()
+	local.RegisterRemoteGroup("group", time.Second, StaticResolver{}, nil)
+
+	time.Sleep(10 * time.Millisecond)
+
+	st := local.Status()
+	assert.False(t, st.OK)
+	assert.Contains(t, st.Failures, "group")
+}
@@ -0,0 +1,73 @@
+package healthz
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Resolver resolves the current set of healthz endpoint URLs for a
+// RegisterRemoteGroup check. Implementations must be safe for concurrent
+// use, since Resolve may be called again before a previous call returns.
+type Resolver interface {
+	Resolve(ctx context.Context) ([]string, error)
+}
+
+// StaticResolver is a Resolver that always resolves to the same fixed list
+// of URLs. Useful for a small, stable set of instances, or in tests.
+type StaticResolver []string
+
+// Resolve implements Resolver.
+func (r StaticResolver) Resolve(ctx context.Context) ([]string, error) {
+	return []string(r), nil
+}
+
+// DNSSRVOption configures a Resolver returned by DNSSRVResolver.
+type DNSSRVOption func(*dnsSRVResolver)
+
+// DNSSRVScheme overrides the URL scheme used by DNSSRVResolver. It defaults
+// to "http".
+func DNSSRVScheme(scheme string) DNSSRVOption {
+	return func(r *dnsSRVResolver) { r.scheme = scheme }
+}
+
+// DNSSRVPath overrides the URL path used by DNSSRVResolver. It defaults to
+// "/healthz".
+func DNSSRVPath(path string) DNSSRVOption {
+	return func(r *dnsSRVResolver) { r.path = path }
+}
+
+type dnsSRVResolver struct {
+	name   string
+	scheme string
+	path   string
+}
+
+// DNSSRVResolver returns a Resolver that looks up the DNS SRV record name
+// (e.g. "_healthz._tcp.myservice.local") and resolves it to
+// "<scheme>://host:port<path>" URLs for RegisterRemoteGroup. It re-resolves
+// the SRV record on every call to Resolve, so scaling events are picked up
+// without a restart.
+func DNSSRVResolver(name string, opts ...DNSSRVOption) Resolver {
+	r := &dnsSRVResolver{name: name, scheme: "http", path: "/healthz"}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Resolve implements Resolver.
+func (r *dnsSRVResolver) Resolve(ctx context.Context) ([]string, error) {
+	_, addrs, err := net.DefaultResolver.LookupSRV(ctx, "", "", r.name)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		host := strings.TrimSuffix(addr.Target, ".")
+		urls = append(urls, fmt.Sprintf("%s://%s:%d%s", r.scheme, host, addr.Port, r.path))
+	}
+	return urls, nil
+}
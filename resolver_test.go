@@ -0,0 +1,31 @@
+package healthz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDNSSRVResolver_defaults(t *testing.T) {
+	r := DNSSRVResolver("_healthz._tcp.example.local").(*dnsSRVResolver)
+	assert.Equal(t, "_healthz._tcp.example.local", r.name)
+	assert.Equal(t, "http", r.scheme)
+	assert.Equal(t, "/healthz", r.path)
+}
+
+func TestDNSSRVResolver_options(t *testing.T) {
+	r := DNSSRVResolver(
+		"_healthz._tcp.example.local",
+		DNSSRVScheme("https"),
+		DNSSRVPath("/status"),
+	).(*dnsSRVResolver)
+	assert.Equal(t, "https", r.scheme)
+	assert.Equal(t, "/status", r.path)
+}
+
+func TestStaticResolver(t *testing.T) {
+	r := StaticResolver{"http://a", "http://b"}
+	urls, err := r.Resolve(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"http://a", "http://b"}, urls)
+}